@@ -0,0 +1,57 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+)
+
+const base32Alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+var base32Decode [256]int8
+
+func init() {
+	for i := range base32Decode {
+		base32Decode[i] = -1
+	}
+	for i, c := range base32Alphabet {
+		base32Decode[c] = int8(i)
+	}
+}
+
+// EncodeBase32 returns the standard geohash base32 string for g (5 bits per
+// character), truncated to at most 12 characters (60 bits).
+func EncodeBase32(g GeoHash) string {
+	chars := (g.Precision() + 4) / 5
+	if chars == 0 {
+		chars = 1
+	} else if chars > 12 {
+		chars = 12
+	}
+
+	hash := g.Hash()
+	buf := make([]byte, chars)
+	for i := uint(0); i < chars; i++ {
+		shift := 64 - 5*(i+1)
+		buf[i] = base32Alphabet[(hash>>shift)&0x1f]
+	}
+	return string(buf)
+}
+
+// DecodeBase32 parses a standard geohash base32 string (1..12 characters)
+// into a GeoHash at precision len(s)*5.
+func DecodeBase32(s string) (GeoHash, error) {
+	if len(s) == 0 || len(s) > 12 {
+		return nil, errors.New("geohash: base32 string must be 1..12 characters")
+	}
+
+	var hash uint64
+	for i := 0; i < len(s); i++ {
+		v := base32Decode[s[i]]
+		if v < 0 {
+			return nil, fmt.Errorf("geohash: invalid base32 character %q", s[i])
+		}
+		hash |= uint64(v) << (64 - 5*(i+1))
+	}
+
+	return FromHash(hash, uint(len(s)*5)), nil
+}
@@ -0,0 +1,176 @@
+package geohash
+
+import "sort"
+
+// HashesInBoundingBox returns a minimal merged set of Range intervals, at
+// precision, covering the rectangle from (topLeftLat, topLeftLon) to
+// (bottomRightLat, bottomRightLon). Latitudes are clamped to the poles.
+// If bottomRightLon < topLeftLon the box is treated as crossing the
+// antimeridian and is split into two boxes joined at +/-180.
+func HashesInBoundingBox(topLeftLat, topLeftLon, bottomRightLat, bottomRightLon float64, precision uint) []Range {
+	if bottomRightLon < topLeftLon {
+		west := HashesInBoundingBox(topLeftLat, topLeftLon, bottomRightLat, 180, precision)
+		east := HashesInBoundingBox(topLeftLat, -180, bottomRightLat, bottomRightLon, precision)
+		// the two halves can touch or share cells; coalesce them
+		ranges := append(west, east...)
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i].Min < ranges[j].Min })
+		merged := ranges[:1]
+		for _, r := range ranges[1:] {
+			last := &merged[len(merged)-1]
+			if last.Max == ^uint64(0) || r.Min <= last.Max+1 {
+				if r.Max > last.Max {
+					last.Max = r.Max
+				}
+			} else {
+				merged = append(merged, r)
+			}
+		}
+		return merged
+	}
+
+	north := topLeftLat
+	if north > 90 {
+		north = 90
+	}
+	south := bottomRightLat
+	if south < -90 {
+		south = -90
+	}
+
+	latPrecision, lonPrecision := splitPrecision(precision)
+	latStep := uint32(1) << (32 - latPrecision)
+	lonStep := uint32(1) << (32 - lonPrecision)
+
+	minLatBits := latBitsFor(south, latPrecision)
+	maxLatBits := latBitsFor(north, latPrecision)
+	minLonBits := lonBitsFor(topLeftLon, lonPrecision)
+	maxLonBits := lonBitsFor(bottomRightLon, lonPrecision)
+
+	var ranges []Range
+	for latBits := minLatBits; ; latBits += latStep {
+		for lonBits := minLonBits; ; lonBits += lonStep {
+			ranges = mergeAdjacent(ranges, cellRange(interleave(latBits, lonBits), precision))
+			if lonBits >= maxLonBits {
+				break
+			}
+		}
+		if latBits >= maxLatBits {
+			break
+		}
+	}
+	return ranges
+}
+
+// HashesInPolygon returns a minimal merged set of Range intervals, at
+// precision, covering the simple polygon described by ring (a closed or
+// open list of [latitude, longitude] points). It scans rows of cells and,
+// for each row, ray-casts ring's edges at the row's center latitude to find
+// the covered longitude spans, merging adjacent cells with the same logic
+// GetHashRangesInside uses for its neighborhood. Only those center-line
+// crossings are sampled: parts of the polygon lying between row centers
+// (for example a polygon smaller than one row) are not covered.
+func HashesInPolygon(ring [][2]float64, precision uint) []Range {
+	if len(ring) < 3 {
+		return nil
+	}
+
+	minLat, maxLat := ring[0][0], ring[0][0]
+	for _, p := range ring {
+		if p[0] < minLat {
+			minLat = p[0]
+		}
+		if p[0] > maxLat {
+			maxLat = p[0]
+		}
+	}
+
+	latPrecision, lonPrecision := splitPrecision(precision)
+	latStep := uint32(1) << (32 - latPrecision)
+	lonStep := uint32(1) << (32 - lonPrecision)
+
+	minLatBits := latBitsFor(minLat, latPrecision)
+	maxLatBits := latBitsFor(maxLat, latPrecision)
+
+	var ranges []Range
+	for latBits := minLatBits; ; latBits += latStep {
+		// row height computed in 64 bits: latStep is 0 at latPrecision 0
+		rowHeight := float64(uint64(1) << (32 - latPrecision))
+		rowLat := -90.0 + (float64(latBits)+rowHeight/2)/(1<<32)*180.0
+
+		for _, span := range scanlineSpans(ring, rowLat) {
+			minLonBits := lonBitsFor(span[0], lonPrecision)
+			maxLonBits := lonBitsFor(span[1], lonPrecision)
+			for lonBits := minLonBits; ; lonBits += lonStep {
+				ranges = mergeAdjacent(ranges, cellRange(interleave(latBits, lonBits), precision))
+				if lonBits >= maxLonBits {
+					break
+				}
+			}
+		}
+
+		if latBits >= maxLatBits {
+			break
+		}
+	}
+	return ranges
+}
+
+// scanlineSpans returns the longitude spans where the horizontal line at
+// latitude lat crosses the interior of ring, via standard ray-casting edge
+// intersection.
+func scanlineSpans(ring [][2]float64, lat float64) [][2]float64 {
+	var xs []float64
+	n := len(ring)
+	for i := 0; i < n; i++ {
+		a := ring[i]
+		b := ring[(i+1)%n]
+		if (a[0] <= lat && b[0] > lat) || (b[0] <= lat && a[0] > lat) {
+			t := (lat - a[0]) / (b[0] - a[0])
+			xs = append(xs, a[1]+t*(b[1]-a[1]))
+		}
+	}
+	sort.Float64s(xs)
+
+	var spans [][2]float64
+	for i := 0; i+1 < len(xs); i += 2 {
+		spans = append(spans, [2]float64{xs[i], xs[i+1]})
+	}
+	return spans
+}
+
+// splitPrecision divides a 64-bit interleaved-hash precision into its
+// latitude and longitude bit counts, the same split GetAdjacent uses.
+// latPrecision+lonPrecision always equals precision; the odd bit (if any)
+// goes to longitude, so precision 1 still distinguishes east from west
+// instead of flooring both axes to 0 bits.
+func splitPrecision(precision uint) (latPrecision, lonPrecision uint) {
+	lonPrecision = (precision + 1) / 2
+	latPrecision = precision - lonPrecision
+	return
+}
+
+func latBitsFor(lat float64, precision uint) uint32 {
+	offset := ((lat + 90.0) / 180.0) * (1 << 32)
+	if offset >= 1<<32 {
+		// lat 90 would overflow uint32; it belongs to the top row
+		offset = 1<<32 - 1
+	}
+	full := uint32(offset)
+	if precision >= 32 {
+		return full
+	}
+	return full &^ (uint32(1<<(32-precision)) - 1)
+}
+
+func lonBitsFor(lon float64, precision uint) uint32 {
+	offset := ((lon + 180.0) / 360.0) * (1 << 32)
+	if offset >= 1<<32 {
+		// lon 180 would overflow uint32; it belongs to the last column
+		offset = 1<<32 - 1
+	}
+	full := uint32(offset)
+	if precision >= 32 {
+		return full
+	}
+	return full &^ (uint32(1<<(32-precision)) - 1)
+}
@@ -0,0 +1,52 @@
+package geohash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHashesInBoundingBoxLowPrecisionDoesNotHang(t *testing.T) {
+	done := make(chan []Range, 1)
+	go func() {
+		done <- HashesInBoundingBox(10, 10, -10, 20, 1)
+	}()
+
+	select {
+	case ranges := <-done:
+		if len(ranges) == 0 {
+			t.Fatal("HashesInBoundingBox returned no ranges")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("HashesInBoundingBox(precision=1) did not return within 3s")
+	}
+}
+
+func TestHashesInBoundingBoxLowPrecisionDistinguishesFarApartBoxes(t *testing.T) {
+	nw := HashesInBoundingBox(80, -170, 70, -160, 1)
+	se := HashesInBoundingBox(-70, 160, -80, 170, 1)
+
+	if len(nw) != 1 || len(se) != 1 {
+		t.Fatalf("expected single-range results, got nw=%v se=%v", nw, se)
+	}
+	if nw[0] == se[0] {
+		t.Fatalf("far-apart boxes at precision 1 produced the same range: %v", nw[0])
+	}
+}
+
+func TestHashesInPolygonLowPrecisionDoesNotHang(t *testing.T) {
+	ring := [][2]float64{{-10, -10}, {-10, 20}, {10, 20}, {10, -10}}
+
+	done := make(chan []Range, 1)
+	go func() {
+		done <- HashesInPolygon(ring, 1)
+	}()
+
+	select {
+	case ranges := <-done:
+		if len(ranges) == 0 {
+			t.Fatal("HashesInPolygon returned no ranges")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("HashesInPolygon(precision=1) did not return within 3s")
+	}
+}
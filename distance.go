@@ -0,0 +1,108 @@
+package geohash
+
+import "math"
+
+// EarthRadiusMeters is the WGS84 equatorial radius (semi-major axis), used
+// as the sphere radius by the distance calculations in this file.
+const EarthRadiusMeters = 6378137
+
+// Distance returns the great-circle distance between a and b, in meters,
+// using the haversine formula.
+func Distance(a, b GeoHash) float64 {
+	lat1, lon1 := a.Coordinates()
+	lat2, lon2 := b.Coordinates()
+	return DistanceCoords(lat1, lon1, lat2, lon2)
+}
+
+// DistanceCoords returns the great-circle distance between two lat/lon
+// points, in meters, using the haversine formula on a WGS84 sphere.
+func DistanceCoords(lat1, lon1, lat2, lon2 float64) float64 {
+	rlat1 := lat1 * math.Pi / 180.0
+	rlat2 := lat2 * math.Pi / 180.0
+	dLat := rlat2 - rlat1
+	dLon := (lon2 - lon1) * math.Pi / 180.0
+
+	sinDLat := math.Sin(dLat / 2)
+	sinDLon := math.Sin(dLon / 2)
+
+	a := sinDLat*sinDLat + math.Cos(rlat1)*math.Cos(rlat2)*sinDLon*sinDLon
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return EarthRadiusMeters * c
+}
+
+// DistanceCoordsFast is DistanceCoords computed with SloppySin/SloppyCos
+// instead of math.Sin/math.Cos (math.Atan2 is still used), for bulk radius
+// filtering where sub-meter accuracy is not required.
+func DistanceCoordsFast(lat1, lon1, lat2, lon2 float64) float64 {
+	rlat1 := lat1 * math.Pi / 180.0
+	rlat2 := lat2 * math.Pi / 180.0
+	dLat := rlat2 - rlat1
+	dLon := (lon2 - lon1) * math.Pi / 180.0
+
+	sinDLat := SloppySin(dLat / 2)
+	sinDLon := SloppySin(dLon / 2)
+
+	a := sinDLat*sinDLat + SloppyCos(rlat1)*SloppyCos(rlat2)*sinDLon*sinDLon
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return EarthRadiusMeters * c
+}
+
+// FilterByRadius returns the subset of candidates within radiusMeters of
+// center. GetHashRangesInside covers a 3x3 neighborhood of cells, which is
+// a superset of the true circular region; this prunes the false positives.
+func FilterByRadius(center GeoHash, candidates []GeoHash, radiusMeters float64) []GeoHash {
+	var result []GeoHash
+	for _, c := range candidates {
+		if Distance(center, c) <= radiusMeters {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// sloppySinTableSize is the number of samples taken over [0, pi/2]; values
+// between samples are linearly interpolated, giving ~1e-7 error.
+const sloppySinTableSize = 1 << 12
+
+var sloppySinTable [sloppySinTableSize + 1]float64
+
+func init() {
+	for i := range sloppySinTable {
+		sloppySinTable[i] = math.Sin(float64(i) * (math.Pi / 2) / sloppySinTableSize)
+	}
+}
+
+// SloppySin approximates math.Sin via a lookup table over [0, pi/2] with
+// symmetry reduction for the rest of the domain, trading ~1e-7 error for
+// speed on bulk computations such as DistanceCoordsFast. Mirrors the
+// approach used by Lucene's SloppyMath.
+func SloppySin(x float64) float64 {
+	if x < 0 {
+		return -SloppySin(-x)
+	}
+
+	x = math.Mod(x, 2*math.Pi)
+	sign := 1.0
+	if x > math.Pi {
+		x -= math.Pi
+		sign = -1.0
+	}
+	if x > math.Pi/2 {
+		x = math.Pi - x
+	}
+
+	idx := x * (2 * sloppySinTableSize / math.Pi)
+	i := int(idx)
+	if i >= sloppySinTableSize {
+		return sign * sloppySinTable[sloppySinTableSize]
+	}
+	frac := idx - float64(i)
+	return sign * (sloppySinTable[i] + frac*(sloppySinTable[i+1]-sloppySinTable[i]))
+}
+
+// SloppyCos approximates math.Cos, built on the same table as SloppySin.
+func SloppyCos(x float64) float64 {
+	return SloppySin(x + math.Pi/2)
+}
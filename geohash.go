@@ -35,8 +35,18 @@ type GeoHash interface {
 	GetNeighbors() [8]GeoHash
 
 	GetHashRangesInside(radius float64) []Range
+
+	// Freeze eagerly computes every lazily-derived representation (hash,
+	// coordinates, bits) and returns the result. fastGeoHash mutates its
+	// lazy fields on first read, so a value is only safe to share across
+	// goroutines once frozen.
+	Freeze() GeoHash
 }
 
+// fastGeoHash lazily computes its hash/coordinates/bits on first read and
+// caches them in place, so a *fastGeoHash must not be read from multiple
+// goroutines concurrently. Call Freeze to get a value with everything
+// precomputed, which is then safe to share.
 type fastGeoHash struct {
 	hash                  uint64
 	latBits               uint32
@@ -116,12 +126,7 @@ func (f *fastGeoHash) GetAdjacent(dir Direction) GeoHash {
 		}
 	}
 
-	lonPrecision := f.precision / 2
-	latPrecision := lonPrecision
-	if f.precision&1 != 0 {
-		// odd precision will cause latitude to be less accurate
-		latPrecision -= 1
-	}
+	latPrecision, lonPrecision := splitPrecision(f.precision)
 
 	latBits := f.latBits
 	lonBits := f.lonBits
@@ -199,6 +204,21 @@ func (f *fastGeoHash) GetNeighbors() [8]GeoHash {
 	}
 }
 
+// Freeze eagerly computes the hash, coordinates and bit representations so
+// the returned GeoHash no longer mutates itself on read.
+func (f *fastGeoHash) Freeze() GeoHash {
+	frozen := &fastGeoHash{
+		hash:      f.Hash(),
+		precision: f.precision,
+	}
+	frozen.lat, frozen.lon = f.Coordinates()
+	frozen.latBits, frozen.lonBits = deinterleave(frozen.hash)
+	frozen.coordinatesCalculated = true
+	frozen.hashCalculated = true
+	frozen.bitsCalculated = true
+	return frozen
+}
+
 func (f *fastGeoHash) GetHashRangesInside(radius float64) []Range {
 	lat, _ := f.Coordinates()
 	bestPrecision := getProximitySearchPrecision(lat, radius)
@@ -206,56 +226,67 @@ func (f *fastGeoHash) GetHashRangesInside(radius float64) []Range {
 	h := f.GetInPrecision(bestPrecision)
 	neighbors := h.GetNeighbors()
 
-	ranges := make([]Range, 1)
-	min := ^uint64(math.MaxUint64 >> bestPrecision)
-	max := uint64(math.MaxUint64 >> bestPrecision)
-	hash := h.Hash()
-	ranges[0] = Range{
-		Min: hash & min,
-		Max: hash | max,
-	}
+	ranges := []Range{cellRange(h.Hash(), bestPrecision)}
 	for _, n := range neighbors {
-		hash = n.Hash()
-		r := Range{
-			Min: hash & min,
-			Max: hash | max,
+		if n == nil {
+			// GetAdjacent returns nil where the neighbor would cross a pole.
+			continue
+		}
+		ranges = mergeAdjacent(ranges, cellRange(n.Hash(), bestPrecision))
+	}
+
+	return ranges
+}
+
+// cellRange returns the Range of 64-bit hash values sharing hash's top
+// precision bits.
+func cellRange(hash uint64, precision uint) Range {
+	var low uint64
+	if precision < 64 {
+		low = (uint64(1) << (64 - precision)) - 1
+	}
+	return Range{Min: hash &^ low, Max: hash | low}
+}
+
+// mergeAdjacent inserts r into ranges, merging it with any range(s) it is
+// directly adjacent to so that the result stays a minimal set of disjoint,
+// non-touching intervals.
+func mergeAdjacent(ranges []Range, r Range) []Range {
+	for _, q := range ranges {
+		if r.Min >= q.Min && r.Max <= q.Max {
+			// r is already covered (e.g. a neighbor that wrapped onto another)
+			return ranges
 		}
-		found := false
-		for i, _ := range ranges {
-			if r.Max+1 == ranges[i].Min {
-				// new range before i
-				ranges[i].Min = r.Min
-				found = true
-				for j := i + 1; j < len(ranges); j++ {
-					if ranges[j].Max+1 == r.Min {
-						ranges[i].Min = ranges[j].Min
-						ranges[j] = ranges[len(ranges)-1]
-						ranges = ranges[:len(ranges)-1]
-						break
-					}
+	}
+	// The "x < y &&" guards keep Max+1 from wrapping to 0 at MaxUint64.
+	for i := range ranges {
+		if r.Max < ranges[i].Min && r.Max+1 == ranges[i].Min {
+			// r slots in just before ranges[i]
+			ranges[i].Min = r.Min
+			for j := i + 1; j < len(ranges); j++ {
+				if ranges[j].Max < ranges[i].Min && ranges[j].Max+1 == ranges[i].Min {
+					ranges[i].Min = ranges[j].Min
+					ranges[j] = ranges[len(ranges)-1]
+					ranges = ranges[:len(ranges)-1]
+					break
 				}
-				break
-			} else if ranges[i].Max+1 == r.Min {
-				// new range after i
-				ranges[i].Max = r.Max
-				found = true
-				for j := i + 1; j < len(ranges); j++ {
-					if r.Max+1 == ranges[j].Min {
-						ranges[i].Max = ranges[j].Max
-						ranges[j] = ranges[len(ranges)-1]
-						ranges = ranges[:len(ranges)-1]
-						break
-					}
+			}
+			return ranges
+		} else if ranges[i].Max < r.Min && ranges[i].Max+1 == r.Min {
+			// r slots in just after ranges[i]
+			ranges[i].Max = r.Max
+			for j := i + 1; j < len(ranges); j++ {
+				if r.Max < ranges[j].Min && r.Max+1 == ranges[j].Min {
+					ranges[i].Max = ranges[j].Max
+					ranges[j] = ranges[len(ranges)-1]
+					ranges = ranges[:len(ranges)-1]
+					break
 				}
-				break
 			}
-		}
-		if !found {
-			ranges = append(ranges, r)
+			return ranges
 		}
 	}
-
-	return ranges
+	return append(ranges, r)
 }
 
 func (f *fastGeoHash) calcBitsFromCoords() {
@@ -0,0 +1,138 @@
+package geohash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GeoJSONPoint is a GeoJSON Point geometry. Per the GeoJSON spec (RFC 7946
+// 3.1.1), Coordinates is ordered [longitude, latitude] -- the opposite of
+// the latitude, longitude order used everywhere else in this package.
+type GeoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// GeoJSONPolygon is a GeoJSON Polygon geometry. Each ring is a list of
+// [longitude, latitude] pairs, first and last equal.
+type GeoJSONPolygon struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// BBox is a GeoJSON bounding box: [west, south, east, north], longitude
+// first as in the rest of this file.
+type BBox [4]float64
+
+// ParseGeoJSONPoint parses a GeoJSON Point into a full-precision GeoHash.
+func ParseGeoJSONPoint(data []byte) (GeoHash, error) {
+	var p GeoJSONPoint
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	if p.Type != "Point" {
+		return nil, fmt.Errorf("geohash: expected GeoJSON type Point, got %q", p.Type)
+	}
+	lon, lat := p.Coordinates[0], p.Coordinates[1]
+	return FromCoordinates(lat, lon), nil
+}
+
+// EncodeGeoJSONPoint emits g's center as a GeoJSON Point.
+func EncodeGeoJSONPoint(g GeoHash) GeoJSONPoint {
+	lat, lon := g.Coordinates()
+	return GeoJSONPoint{Type: "Point", Coordinates: [2]float64{lon, lat}}
+}
+
+// EncodeGeoJSONPolygon emits g's bounding rectangle as a GeoJSON Polygon.
+func EncodeGeoJSONPolygon(g GeoHash) GeoJSONPolygon {
+	south, west, north, east := cellBounds(g)
+	ring := [][2]float64{
+		{west, south},
+		{east, south},
+		{east, north},
+		{west, north},
+		{west, south},
+	}
+	return GeoJSONPolygon{Type: "Polygon", Coordinates: [][][2]float64{ring}}
+}
+
+// ParseGeoJSONBBox converts a GeoJSON bbox ([west, south, east, north])
+// into a Range set at precision, via HashesInBoundingBox.
+func ParseGeoJSONBBox(box BBox, precision uint) []Range {
+	west, south, east, north := box[0], box[1], box[2], box[3]
+	return HashesInBoundingBox(north, west, south, east, precision)
+}
+
+// ParseGeoJSONPolygon parses a GeoJSON Polygon's outer ring into a Range
+// set at precision, via HashesInPolygon.
+func ParseGeoJSONPolygon(data []byte, precision uint) ([]Range, error) {
+	var p GeoJSONPolygon
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	if p.Type != "Polygon" || len(p.Coordinates) == 0 {
+		return nil, fmt.Errorf("geohash: expected GeoJSON type Polygon with a ring, got %q", p.Type)
+	}
+
+	outer := p.Coordinates[0]
+	ring := make([][2]float64, len(outer))
+	for i, c := range outer {
+		// GeoJSON is lon,lat; this package's ring type is lat,lon.
+		ring[i] = [2]float64{c[1], c[0]}
+	}
+	return HashesInPolygon(ring, precision), nil
+}
+
+// ParseWKTPoint parses a WKT "POINT(lon lat)" string into a full-precision
+// GeoHash, using WKT's longitude-first ordering.
+func ParseWKTPoint(wkt string) (GeoHash, error) {
+	wkt = strings.TrimSpace(wkt)
+	if !strings.HasPrefix(strings.ToUpper(wkt), "POINT") {
+		return nil, errors.New("geohash: not a WKT POINT")
+	}
+
+	open := strings.IndexByte(wkt, '(')
+	closeParen := strings.LastIndexByte(wkt, ')')
+	if open < 0 || closeParen < 0 || closeParen < open {
+		return nil, errors.New("geohash: malformed WKT POINT")
+	}
+
+	fields := strings.Fields(wkt[open+1 : closeParen])
+	if len(fields) != 2 {
+		return nil, errors.New("geohash: WKT POINT requires exactly lon and lat")
+	}
+
+	lon, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("geohash: invalid WKT longitude: %w", err)
+	}
+	lat, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("geohash: invalid WKT latitude: %w", err)
+	}
+
+	return FromCoordinates(lat, lon), nil
+}
+
+// cellBounds returns the bounding rectangle of g's cell, derived from the
+// interleaved hash bits below g's precision.
+func cellBounds(g GeoHash) (south, west, north, east float64) {
+	hash := g.Hash()
+	precision := g.Precision()
+
+	var low uint64
+	if precision < 64 {
+		low = (uint64(1) << (64 - precision)) - 1
+	}
+	minLatBits, minLonBits := deinterleave(hash &^ low)
+	maxLatBits, maxLonBits := deinterleave(hash | low)
+
+	south = -90.0 + float64(minLatBits)/(1<<32)*180.0
+	north = -90.0 + (float64(maxLatBits)+1)/(1<<32)*180.0
+	west = -180.0 + float64(minLonBits)/(1<<32)*360.0
+	east = -180.0 + (float64(maxLonBits)+1)/(1<<32)*360.0
+	return
+}
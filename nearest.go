@@ -0,0 +1,68 @@
+package geohash
+
+import (
+	"math"
+	"sort"
+)
+
+// maxNearestNeighborRadius bounds the expanding ring search in
+// NearestNeighbors; any search radius past it covers the whole globe.
+const maxNearestNeighborRadius = 20000000 // meters, just under half the earth's circumference
+
+// NearestNeighbors returns up to k entries nearest to (lat, lon), sorted by
+// distance. source is queried for candidates covering each Range of a
+// growing proximity search: NearestNeighbors starts at a fine precision,
+// queries the surrounding 3x3 neighborhood via GetHashRangesInside, and
+// doubles the radius (deduplicating already-visited ranges) until it has
+// collected at least k results whose k-th distance is closer than the
+// radius already scanned -- which guarantees nothing closer remains
+// unscanned.
+func NearestNeighbors(lat, lon float64, k int, source func(Range) []Entry) []Entry {
+	if k <= 0 {
+		return nil
+	}
+
+	center := FromCoordinates(lat, lon)
+	visited := make(map[Range]bool)
+	seen := make(map[Entry]bool)
+	var candidates []Entry
+
+	for radius := 50.0; ; radius *= 2 {
+		bestPrecision := getProximitySearchPrecision(lat, radius)
+		for _, r := range center.GetInPrecision(bestPrecision).GetHashRangesInside(radius) {
+			if visited[r] {
+				continue
+			}
+			visited[r] = true
+			for _, e := range source(r) {
+				if !seen[e] {
+					seen[e] = true
+					candidates = append(candidates, e)
+				}
+			}
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return entryDistance(lat, lon, candidates[i]) < entryDistance(lat, lon, candidates[j])
+		})
+
+		if len(candidates) >= k && entryDistance(lat, lon, candidates[k-1]) < radius {
+			return candidates[:k]
+		}
+		if radius > maxNearestNeighborRadius {
+			if len(candidates) > k {
+				return candidates[:k]
+			}
+			return candidates
+		}
+	}
+}
+
+func entryDistance(lat, lon float64, e Entry) float64 {
+	g, err := DecodeBase32(e.Hash)
+	if err != nil {
+		return math.Inf(1)
+	}
+	glat, glon := g.Coordinates()
+	return DistanceCoords(lat, lon, glat, glon)
+}
@@ -0,0 +1,71 @@
+package geohash
+
+import "testing"
+
+func TestNearestNeighborsDoesNotPanicWhenFewerThanKExist(t *testing.T) {
+	entry := Entry{Hash: EncodeBase32(FromCoordinates(0, 0).GetInPrecision(40)), Payload: "only"}
+	source := func(r Range) []Entry {
+		g, err := DecodeBase32(entry.Hash)
+		if err != nil {
+			return nil
+		}
+		if g.Hash() >= r.Min && g.Hash() <= r.Max {
+			return []Entry{entry}
+		}
+		return nil
+	}
+
+	results := NearestNeighbors(0, 0, 2, source)
+
+	if len(results) != 1 || results[0].Payload != "only" {
+		t.Fatalf("NearestNeighbors(k=2) with one entry = %v, want [only]", results)
+	}
+}
+
+func TestNearestNeighborsDedupesAcrossRadiusDoublings(t *testing.T) {
+	points := []struct {
+		lat, lon float64
+		payload  string
+	}{
+		{0, 0, "a"},
+		{0.01, 0, "b"},
+		{0, 0.01, "c"},
+	}
+
+	var entries []Entry
+	for _, p := range points {
+		entries = append(entries, Entry{
+			Hash:    EncodeBase32(FromCoordinates(p.lat, p.lon).GetInPrecision(50)),
+			Payload: p.payload,
+		})
+	}
+
+	source := func(r Range) []Entry {
+		var matches []Entry
+		for _, e := range entries {
+			g, err := DecodeBase32(e.Hash)
+			if err != nil {
+				continue
+			}
+			if g.Hash() >= r.Min && g.Hash() <= r.Max {
+				matches = append(matches, e)
+			}
+		}
+		return matches
+	}
+
+	results := NearestNeighbors(0, 0, 3, source)
+
+	seen := make(map[string]int)
+	for _, e := range results {
+		seen[e.Payload.(string)]++
+	}
+	if len(results) != 3 {
+		t.Fatalf("NearestNeighbors(k=3) returned %d results, want 3: %v", len(results), results)
+	}
+	for payload, count := range seen {
+		if count != 1 {
+			t.Fatalf("payload %q returned %d times, want 1", payload, count)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package geohash
+
+import "sync"
+
+// Store is a concurrency-safe in-memory index of geohash entries, guarded
+// by a sync.RWMutex. fastGeoHash is not itself safe for concurrent access
+// (see its doc comment); Store never keeps a GeoHash, only uint64 cell keys
+// and base32 strings, so none is shared between goroutines. Because Add is
+// keyed by precision-masked hash
+// rather than full precision, a cell can hold more than one payload (the
+// normal case at coarse precision), so entries are kept as a set per cell.
+type Store struct {
+	mu      sync.RWMutex
+	entries map[uint64]map[interface{}]Entry
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{entries: make(map[uint64]map[interface{}]Entry)}
+}
+
+// Add indexes payload under hash truncated to precision, alongside any other
+// payloads already indexed in the same cell. payload must be a comparable
+// type, since it is used as a map key internally.
+func (s *Store) Add(hash uint64, precision uint, payload interface{}) {
+	g := FromHash(hash, precision).Freeze()
+	e := Entry{Hash: EncodeBase32(g), Payload: payload}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cell := s.entries[g.Hash()]
+	if cell == nil {
+		cell = make(map[interface{}]Entry)
+		s.entries[g.Hash()] = cell
+	}
+	cell[payload] = e
+}
+
+// Remove deletes payload from the entry previously added under
+// hash/precision, if any, pruning the cell once it's empty.
+func (s *Store) Remove(hash uint64, precision uint, payload interface{}) {
+	g := FromHash(hash, precision)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cell := s.entries[g.Hash()]
+	if cell == nil {
+		return
+	}
+	delete(cell, payload)
+	if len(cell) == 0 {
+		delete(s.entries, g.Hash())
+	}
+}
+
+// Query returns every stored entry whose hash falls within any of ranges.
+func (s *Store) Query(ranges []Range) []Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []Entry
+	for hash, cell := range s.entries {
+		for _, r := range ranges {
+			if hash >= r.Min && hash <= r.Max {
+				for _, e := range cell {
+					result = append(result, e)
+				}
+				break
+			}
+		}
+	}
+	return result
+}
+
+// BatchAdd indexes multiple entries under a single write lock.
+func (s *Store) BatchAdd(entries []Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range entries {
+		g, err := DecodeBase32(e.Hash)
+		if err != nil {
+			continue
+		}
+		cell := s.entries[g.Hash()]
+		if cell == nil {
+			cell = make(map[interface{}]Entry)
+			s.entries[g.Hash()] = cell
+		}
+		cell[e.Payload] = e
+	}
+}
@@ -0,0 +1,36 @@
+package geohash
+
+import "testing"
+
+func TestStoreAddKeepsDistinctPayloadsInTheSameCell(t *testing.T) {
+	s := NewStore()
+	h1 := FromCoordinates(10, 10).Hash()
+	h2 := FromCoordinates(10.0001, 10.0001).Hash()
+
+	s.Add(h1, 20, "A")
+	s.Add(h2, 20, "B")
+
+	results := s.Query([]Range{{Min: 0, Max: ^uint64(0)}})
+	payloads := make(map[interface{}]bool)
+	for _, e := range results {
+		payloads[e.Payload] = true
+	}
+	if !payloads["A"] || !payloads["B"] {
+		t.Fatalf("Query = %v, want both A and B", results)
+	}
+}
+
+func TestStoreRemoveOnlyDropsItsOwnPayload(t *testing.T) {
+	s := NewStore()
+	h1 := FromCoordinates(10, 10).Hash()
+	h2 := FromCoordinates(10.0001, 10.0001).Hash()
+
+	s.Add(h1, 20, "A")
+	s.Add(h2, 20, "B")
+	s.Remove(h1, 20, "A")
+
+	results := s.Query([]Range{{Min: 0, Max: ^uint64(0)}})
+	if len(results) != 1 || results[0].Payload != "B" {
+		t.Fatalf("Query after Remove = %v, want only B", results)
+	}
+}
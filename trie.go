@@ -0,0 +1,202 @@
+package geohash
+
+// Entry pairs a base32 geohash string with an arbitrary payload, as
+// returned by PrefixIndex and NearestNeighbors queries.
+type Entry struct {
+	Hash    string
+	Payload interface{}
+}
+
+type trieNode struct {
+	children  [32]*trieNode
+	passCount int
+	payloads  map[interface{}]struct{}
+}
+
+// PrefixIndex is a 32-way trie over base32 geohash strings. Query cost
+// scales with prefix length rather than the number of indexed entries,
+// which is the property that makes a trie preferable to a sorted list for
+// this workload.
+type PrefixIndex struct {
+	root *trieNode
+}
+
+// NewPrefixIndex returns an empty PrefixIndex.
+func NewPrefixIndex() *PrefixIndex {
+	return &PrefixIndex{root: &trieNode{}}
+}
+
+// Insert indexes payload under hash. hash must consist of characters from
+// the base32 alphabet; invalid characters cause the insert to be dropped.
+// payload must be a comparable type (it is used as a map key internally);
+// a non-comparable payload such as a slice, map or func is dropped the same
+// way an invalid hash character is, rather than panicking the caller.
+func (idx *PrefixIndex) Insert(hash string, payload interface{}) {
+	if !isComparable(payload) {
+		return
+	}
+
+	// Validate hash and skip an already-indexed payload before touching any
+	// passCount, so a dropped or repeated insert leaves nothing Delete can't prune.
+	existing := idx.root
+	for i := 0; i < len(hash); i++ {
+		c := base32Decode[hash[i]]
+		if c < 0 {
+			return
+		}
+		if existing != nil {
+			existing = existing.children[c]
+		}
+	}
+	if existing != nil {
+		if _, ok := existing.payloads[payload]; ok {
+			return
+		}
+	}
+
+	n := idx.root
+	n.passCount++
+	for i := 0; i < len(hash); i++ {
+		c := base32Decode[hash[i]]
+		if c < 0 {
+			return
+		}
+		if n.children[c] == nil {
+			n.children[c] = &trieNode{}
+		}
+		n = n.children[c]
+		n.passCount++
+	}
+	if n.payloads == nil {
+		n.payloads = make(map[interface{}]struct{})
+	}
+	n.payloads[payload] = struct{}{}
+}
+
+// isComparable reports whether payload can be used as a map key, by
+// attempting a throwaway lookup and recovering from the panic a
+// non-comparable type (slice, map, func) would otherwise raise.
+func isComparable(payload interface{}) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	m := map[interface{}]struct{}{}
+	_ = m[payload]
+	return true
+}
+
+// Delete removes payload from hash, pruning any trie nodes left empty.
+// payload must be the same comparable value it was Inserted with; a
+// non-comparable payload is treated as not present, same as Insert drops it.
+func (idx *PrefixIndex) Delete(hash string, payload interface{}) {
+	if !isComparable(payload) {
+		// can't have been present; Insert would have refused it.
+		return
+	}
+
+	path := make([]*trieNode, 1, len(hash)+1)
+	path[0] = idx.root
+
+	n := idx.root
+	for i := 0; i < len(hash); i++ {
+		c := base32Decode[hash[i]]
+		if c < 0 || n.children[c] == nil {
+			return
+		}
+		n = n.children[c]
+		path = append(path, n)
+	}
+	if _, ok := n.payloads[payload]; !ok {
+		// payload was never indexed under hash; nothing to prune.
+		return
+	}
+	delete(n.payloads, payload)
+
+	for i := len(path) - 1; i > 0; i-- {
+		path[i].passCount--
+		if path[i].passCount == 0 {
+			path[i-1].children[base32Decode[hash[i-1]]] = nil
+		}
+	}
+	path[0].passCount--
+}
+
+// RangeQuery returns every entry indexed under a hash starting with prefix.
+func (idx *PrefixIndex) RangeQuery(prefix string) []Entry {
+	n := idx.root
+	for i := 0; i < len(prefix); i++ {
+		c := base32Decode[prefix[i]]
+		if c < 0 || n.children[c] == nil {
+			return nil
+		}
+		n = n.children[c]
+	}
+
+	var entries []Entry
+	collectEntries(n, prefix, &entries)
+	return entries
+}
+
+func collectEntries(n *trieNode, prefix string, entries *[]Entry) {
+	for payload := range n.payloads {
+		*entries = append(*entries, Entry{Hash: prefix, Payload: payload})
+	}
+	for c, child := range n.children {
+		if child != nil {
+			collectEntries(child, prefix+base32Alphabet[c:c+1], entries)
+		}
+	}
+}
+
+// ProximityQuery returns every entry within radiusMeters of (lat, lon). It
+// enumerates the candidate Ranges via GetHashRangesInside and, for each
+// one, walks the trie level by level, pruning any subtree whose full bit
+// range doesn't overlap the query Range. Unlike truncating to a whole
+// base32 character, this keeps cost proportional to prefix length even
+// when the search radius is coarser than 5 bits; like GetHashRangesInside
+// it may still return a superset of the true circular region, so filter
+// the result with FilterByRadius for an exact fit.
+func (idx *PrefixIndex) ProximityQuery(lat, lon, radiusMeters float64) []Entry {
+	center := FromCoordinates(lat, lon)
+
+	seen := make(map[Entry]bool)
+	var entries []Entry
+	for _, r := range center.GetHashRangesInside(radiusMeters) {
+		collectInRange(idx.root, "", r, seen, &entries)
+	}
+	return entries
+}
+
+func collectInRange(n *trieNode, prefix string, r Range, seen map[Entry]bool, entries *[]Entry) {
+	bits, hash := prefixBits(prefix)
+	if bounds := cellRange(hash, bits); bounds.Max < r.Min || bounds.Min > r.Max {
+		return
+	}
+
+	for payload := range n.payloads {
+		e := Entry{Hash: prefix, Payload: payload}
+		if !seen[e] {
+			seen[e] = true
+			*entries = append(*entries, e)
+		}
+	}
+	for c, child := range n.children {
+		if child != nil {
+			collectInRange(child, prefix+base32Alphabet[c:c+1], r, seen, entries)
+		}
+	}
+}
+
+// prefixBits decodes prefix into its bit precision and the corresponding
+// 64-bit hash value (zero-padded in the low bits). Characters beyond the
+// 64-bit hash's 12-character capacity don't add precision; they're simply
+// ignored, same as EncodeBase32/DecodeBase32's 12-character cap.
+func prefixBits(prefix string) (bits uint, hash uint64) {
+	for i := 0; i < len(prefix) && 5*(i+1) <= 64; i++ {
+		hash |= uint64(base32Decode[prefix[i]]) << (64 - 5*uint(i+1))
+		bits = uint(5 * (i + 1))
+	}
+	return
+}
@@ -0,0 +1,66 @@
+package geohash
+
+import "testing"
+
+func TestPrefixIndexDoubleDeleteDoesNotPruneOtherEntries(t *testing.T) {
+	idx := NewPrefixIndex()
+	idx.Insert("bc", "A")
+	idx.Insert("bcd", "B")
+
+	idx.Delete("bc", "nonexistent-1")
+	idx.Delete("bc", "nonexistent-2")
+
+	entries := idx.RangeQuery("bcd")
+	if len(entries) != 1 || entries[0].Payload != "B" {
+		t.Fatalf("RangeQuery(%q) = %v, want [{bcd B}]", "bcd", entries)
+	}
+}
+
+func TestPrefixIndexInsertDeleteRoundTrip(t *testing.T) {
+	idx := NewPrefixIndex()
+	idx.Insert("bcd", "A")
+	idx.Insert("bce", "B")
+
+	idx.Delete("bcd", "A")
+
+	if entries := idx.RangeQuery("bcd"); len(entries) != 0 {
+		t.Fatalf("RangeQuery(%q) after delete = %v, want none", "bcd", entries)
+	}
+	if entries := idx.RangeQuery("bce"); len(entries) != 1 || entries[0].Payload != "B" {
+		t.Fatalf("RangeQuery(%q) = %v, want [{bce B}]", "bce", entries)
+	}
+}
+
+func TestPrefixIndexInsertIgnoresNonComparablePayload(t *testing.T) {
+	idx := NewPrefixIndex()
+	idx.Insert("bcd", []int{1, 2, 3})
+
+	if entries := idx.RangeQuery("bcd"); len(entries) != 0 {
+		t.Fatalf("RangeQuery(%q) = %v, want none (non-comparable payload should be dropped)", "bcd", entries)
+	}
+}
+
+func TestPrefixIndexProximityQueryDedupesAtCoarsePrecision(t *testing.T) {
+	idx := NewPrefixIndex()
+
+	nearHash := EncodeBase32(FromCoordinates(0, 0).GetInPrecision(60))
+	farHash := EncodeBase32(FromCoordinates(40, 40).GetInPrecision(60))
+
+	idx.Insert(nearHash, "near")
+	idx.Insert(farHash, "far")
+
+	entries := idx.ProximityQuery(0, 0, 8_000_000)
+
+	counts := make(map[interface{}]int)
+	for _, e := range entries {
+		counts[e.Payload]++
+	}
+	for payload, count := range counts {
+		if count != 1 {
+			t.Fatalf("payload %v returned %d times, want 1", payload, count)
+		}
+	}
+	if counts["near"] != 1 {
+		t.Fatalf("ProximityQuery did not return the near entry: %v", entries)
+	}
+}